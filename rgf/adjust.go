@@ -0,0 +1,77 @@
+package rgf
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// channelAdjustImage lazily applies a per-channel tone curve to src's red,
+// green and blue channels; alpha is passed through unchanged.
+type channelAdjustImage struct {
+	src   image.Image
+	apply func(y float64) float64
+}
+
+func (img channelAdjustImage) Bounds() image.Rectangle { return img.src.Bounds() }
+func (img channelAdjustImage) ColorModel() color.Model { return img.src.ColorModel() }
+func (img channelAdjustImage) At(x, y int) color.Color {
+	r, g, b, a := img.src.At(x, y).RGBA()
+	return color.NRGBA64{
+		R: adjustChannel16(r, img.apply),
+		G: adjustChannel16(g, img.apply),
+		B: adjustChannel16(b, img.apply),
+		A: uint16(a),
+	}
+}
+
+// adjustChannel16 runs a single 16-bit channel value through a normalized
+// (0..1) tone curve.
+func adjustChannel16(v uint32, apply func(y float64) float64) uint16 {
+	y := apply(float64(v) / 0xffff)
+	return clampChannel16(y * 0xffff)
+}
+
+// clamp01 clamps y to the [0, 1] range.
+func clamp01(y float64) float64 {
+	if y < 0 {
+		return 0
+	}
+	if y > 1 {
+		return 1
+	}
+	return y
+}
+
+// brightnessContrast is the shared curve behind AdjustBrightness and
+// AdjustContrast: y' = clamp((y-0.5)*(1+contrast)+0.5+brightness).
+func brightnessContrast(y, brightness, contrast float64) float64 {
+	return clamp01((y-0.5)*(1+contrast) + 0.5 + brightness)
+}
+
+// AdjustBrightness returns a view of src shifted by percent (roughly
+// -100..100), following y' = clamp(y+percent/100).
+func AdjustBrightness(src image.Image, percent float64) image.Image {
+	brightness := percent / 100
+	return channelAdjustImage{src: src, apply: func(y float64) float64 {
+		return brightnessContrast(y, brightness, 0)
+	}}
+}
+
+// AdjustContrast returns a view of src with its contrast scaled by percent
+// (roughly -100..100), following y' = clamp((y-0.5)*(1+percent/100)+0.5).
+func AdjustContrast(src image.Image, percent float64) image.Image {
+	contrast := percent / 100
+	return channelAdjustImage{src: src, apply: func(y float64) float64 {
+		return brightnessContrast(y, 0, contrast)
+	}}
+}
+
+// AdjustGamma returns a view of src with gamma correction applied,
+// following y' = y^(1/gamma). gamma > 1 brightens midtones, gamma < 1
+// darkens them.
+func AdjustGamma(src image.Image, gamma float64) image.Image {
+	return channelAdjustImage{src: src, apply: func(y float64) float64 {
+		return clamp01(math.Pow(y, 1/gamma))
+	}}
+}
@@ -0,0 +1,58 @@
+package rgf
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func grayPixel(y uint8) *image.Gray {
+	src := image.NewGray(image.Rect(0, 0, 1, 1))
+	src.SetGray(0, 0, color.Gray{Y: y})
+	return src
+}
+
+func TestAdjustGammaBrightensAndDarkens(t *testing.T) {
+	src := grayPixel(128)
+	orig := colorToGray(src.At(0, 0)).Y
+
+	if bright := colorToGray(AdjustGamma(src, 2.2).At(0, 0)).Y; bright <= orig {
+		t.Errorf("AdjustGamma(src, 2.2) luminance = %d, want brighter than %d", bright, orig)
+	}
+	if dark := colorToGray(AdjustGamma(src, 0.5).At(0, 0)).Y; dark >= orig {
+		t.Errorf("AdjustGamma(src, 0.5) luminance = %d, want darker than %d", dark, orig)
+	}
+}
+
+func TestAdjustBrightness(t *testing.T) {
+	src := grayPixel(128)
+	orig := colorToGray(src.At(0, 0)).Y
+
+	if got := colorToGray(AdjustBrightness(src, 20).At(0, 0)).Y; got <= orig {
+		t.Errorf("AdjustBrightness(src, 20) luminance = %d, want brighter than %d", got, orig)
+	}
+	if got := colorToGray(AdjustBrightness(src, -20).At(0, 0)).Y; got >= orig {
+		t.Errorf("AdjustBrightness(src, -20) luminance = %d, want darker than %d", got, orig)
+	}
+}
+
+func TestAdjustContrastPushesAwayFromMidpoint(t *testing.T) {
+	src := grayPixel(64) // below the 50% midpoint
+	orig := colorToGray(src.At(0, 0)).Y
+
+	if got := colorToGray(AdjustContrast(src, 50).At(0, 0)).Y; got >= orig {
+		t.Errorf("AdjustContrast(src, 50) luminance = %d, want darker than %d (pushed away from midpoint)", got, orig)
+	}
+	if got := colorToGray(AdjustContrast(src, -50).At(0, 0)).Y; got <= orig {
+		t.Errorf("AdjustContrast(src, -50) luminance = %d, want brighter than %d (pulled toward midpoint)", got, orig)
+	}
+}
+
+func TestAdjustClampsToValidRange(t *testing.T) {
+	if got := colorToGray(AdjustBrightness(grayPixel(255), 100).At(0, 0)).Y; got != 255 {
+		t.Errorf("AdjustBrightness(white, 100) luminance = %d, want clamped to 255", got)
+	}
+	if got := colorToGray(AdjustBrightness(grayPixel(0), -100).At(0, 0)).Y; got != 0 {
+		t.Errorf("AdjustBrightness(black, -100) luminance = %d, want clamped to 0", got)
+	}
+}
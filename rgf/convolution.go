@@ -0,0 +1,79 @@
+package rgf
+
+import (
+	"image"
+	"image/color"
+)
+
+var (
+	// KernelSharpen emphasizes edges by boosting the centre pixel against
+	// its four direct neighbours.
+	KernelSharpen = [9]float64{
+		0, -1, 0,
+		-1, 5, -1,
+		0, -1, 0,
+	}
+
+	// KernelUnsharpMask is a stronger sharpening kernel that also weighs
+	// in the diagonal neighbours.
+	KernelUnsharpMask = [9]float64{
+		-1, -1, -1,
+		-1, 9, -1,
+		-1, -1, -1,
+	}
+
+	// KernelEdgeDetect highlights edges and flattens uniform areas to
+	// black.
+	KernelEdgeDetect = [9]float64{
+		-1, -1, -1,
+		-1, 8, -1,
+		-1, -1, -1,
+	}
+)
+
+// Convolve applies a 3x3 convolution kernel (row-major, top-left to
+// bottom-right) to src's colour channels, adding bias afterwards. Alpha is
+// passed through unchanged. Taps that fall outside src's bounds are
+// clamped to the nearest edge pixel.
+func Convolve(src image.Image, kernel [9]float64, bias float64) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dst := image.NewNRGBA64(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var r, g, bl float64
+			for ky := -1; ky <= 1; ky++ {
+				for kx := -1; kx <= 1; kx++ {
+					sx := clampIndex(x+kx, w)
+					sy := clampIndex(y+ky, h)
+					cr, cg, cb, _ := src.At(b.Min.X+sx, b.Min.Y+sy).RGBA()
+					weight := kernel[(ky+1)*3+(kx+1)]
+					r += float64(cr) * weight
+					g += float64(cg) * weight
+					bl += float64(cb) * weight
+				}
+			}
+			_, _, _, a := src.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			dst.SetNRGBA64(x, y, color.NRGBA64{
+				R: clampChannel16(r + bias*0xffff),
+				G: clampChannel16(g + bias*0xffff),
+				B: clampChannel16(bl + bias*0xffff),
+				A: uint16(a),
+			})
+		}
+	}
+	return dst
+}
+
+// clampIndex clamps v to the valid [0, n) range, repeating the edge index
+// for out-of-bounds convolution taps.
+func clampIndex(v, n int) int {
+	if v < 0 {
+		return 0
+	}
+	if v >= n {
+		return n - 1
+	}
+	return v
+}
@@ -0,0 +1,50 @@
+package rgf
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestConvolveClampsAtEdges(t *testing.T) {
+	// On a 1x1 image every one of KernelSharpen's 9 taps clamps to the
+	// only pixel there is. KernelSharpen's weights sum to 1, so the
+	// result should reproduce the original value exactly.
+	src := grayPixel(100)
+
+	got := colorToGray(Convolve(src, KernelSharpen, 0).At(0, 0)).Y
+	if got != 100 {
+		t.Errorf("Convolve(1x1, KernelSharpen, 0) = %d, want 100 (all taps clamp to the only pixel)", got)
+	}
+}
+
+func TestConvolveEdgeDetectFlatImageIsZero(t *testing.T) {
+	// KernelEdgeDetect's weights sum to 0, so a uniform image should
+	// convolve to solid black away from the clamped border.
+	src := image.NewGray(image.Rect(0, 0, 3, 3))
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			src.SetGray(x, y, color.Gray{Y: 100})
+		}
+	}
+
+	got := colorToGray(Convolve(src, KernelEdgeDetect, 0).At(1, 1)).Y
+	if got != 0 {
+		t.Errorf("Convolve(flat 3x3, KernelEdgeDetect, 0) centre pixel = %d, want 0", got)
+	}
+}
+
+func TestConvolveBias(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 3, 3))
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			src.SetGray(x, y, color.Gray{Y: 100})
+		}
+	}
+	zeroKernel := [9]float64{}
+
+	got := colorToGray(Convolve(src, zeroKernel, 0.5).At(1, 1)).Y
+	if got != 128 && got != 127 {
+		t.Errorf("Convolve(flat, zero kernel, bias 0.5) centre pixel = %d, want ~128", got)
+	}
+}
@@ -0,0 +1,188 @@
+package rgf
+
+import "image"
+
+// DitherKernel selects the algorithm used by ByDitherKernel to turn a
+// grayscale image into a 1-bit bitmap. The predefined kernels below cover
+// the common error-diffusion and ordered dithering families.
+type DitherKernel interface {
+	// apply quantizes grayMap (a w*h, row-major, 0..1 luminance map) to 0/1
+	// values in place.
+	apply(grayMap []float64, w, h int)
+}
+
+// errorDiffusionKernel is a DitherKernel that, after quantizing a pixel,
+// spreads the quantization error to not-yet-visited neighbours according to
+// a fixed table of (dx, dy, numerator) offsets and a shared divisor.
+type errorDiffusionKernel struct {
+	offsets []ditherOffset
+	divisor float64
+}
+
+// ditherOffset is a single (dx, dy, numerator) entry of an error-diffusion
+// kernel.
+type ditherOffset struct {
+	dx, dy int
+	num    float64
+}
+
+// orderedKernel is a DitherKernel that thresholds each pixel against a
+// fixed, tiled threshold map, without propagating any error.
+type orderedKernel struct {
+	size       int
+	thresholds [][]float64
+}
+
+var (
+	// KernelFloydSteinberg is the classic Floyd-Steinberg error-diffusion
+	// kernel. This is what ByDither has always used.
+	// https://en.wikipedia.org/wiki/Floyd%E2%80%93Steinberg_dithering
+	KernelFloydSteinberg DitherKernel = errorDiffusionKernel{
+		offsets: []ditherOffset{
+			{1, 0, 7},
+			{-1, 1, 3},
+			{0, 1, 5},
+			{1, 1, 1},
+		},
+		divisor: 16,
+	}
+
+	// KernelAtkinson is Bill Atkinson's kernel. It only diffuses 6/8 of the
+	// quantization error, which gives it its characteristic "light" look
+	// with more pure white/black areas than Floyd-Steinberg.
+	KernelAtkinson DitherKernel = errorDiffusionKernel{
+		offsets: []ditherOffset{
+			{1, 0, 1},
+			{2, 0, 1},
+			{-1, 1, 1},
+			{0, 1, 1},
+			{1, 1, 1},
+			{0, 2, 1},
+		},
+		divisor: 8,
+	}
+
+	// KernelJarvisJudiceNinke is the Jarvis, Judice & Ninke kernel. It
+	// spreads error over a wider neighbourhood than Floyd-Steinberg, which
+	// reduces visible patterning at the cost of more blur.
+	KernelJarvisJudiceNinke DitherKernel = errorDiffusionKernel{
+		offsets: []ditherOffset{
+			{1, 0, 7}, {2, 0, 5},
+			{-2, 1, 3}, {-1, 1, 5}, {0, 1, 7}, {1, 1, 5}, {2, 1, 3},
+			{-2, 2, 1}, {-1, 2, 3}, {0, 2, 5}, {1, 2, 3}, {2, 2, 1},
+		},
+		divisor: 48,
+	}
+
+	// KernelStucki is the Stucki kernel, a sharper variant of
+	// Jarvis-Judice-Ninke.
+	KernelStucki DitherKernel = errorDiffusionKernel{
+		offsets: []ditherOffset{
+			{1, 0, 8}, {2, 0, 4},
+			{-2, 1, 2}, {-1, 1, 4}, {0, 1, 8}, {1, 1, 4}, {2, 1, 2},
+			{-2, 2, 1}, {-1, 2, 2}, {0, 2, 4}, {1, 2, 2}, {2, 2, 1},
+		},
+		divisor: 42,
+	}
+
+	// KernelSierra is the (full, three-row) Sierra kernel.
+	KernelSierra DitherKernel = errorDiffusionKernel{
+		offsets: []ditherOffset{
+			{1, 0, 5}, {2, 0, 3},
+			{-2, 1, 2}, {-1, 1, 4}, {0, 1, 5}, {1, 1, 4}, {2, 1, 2},
+			{-1, 2, 2}, {0, 2, 3}, {1, 2, 2},
+		},
+		divisor: 32,
+	}
+
+	// KernelBayer4x4 is a 4x4 ordered dithering threshold map.
+	KernelBayer4x4 DitherKernel = newOrderedKernel([][]int{
+		{0, 8, 2, 10},
+		{12, 4, 14, 6},
+		{3, 11, 1, 9},
+		{15, 7, 13, 5},
+	})
+
+	// KernelBayer8x8 is an 8x8 ordered dithering threshold map. It produces
+	// a finer, less visible pattern than KernelBayer4x4.
+	KernelBayer8x8 DitherKernel = newOrderedKernel([][]int{
+		{0, 48, 12, 60, 3, 51, 15, 63},
+		{32, 16, 44, 28, 35, 19, 47, 31},
+		{8, 56, 4, 52, 11, 59, 7, 55},
+		{40, 24, 36, 20, 43, 27, 39, 23},
+		{2, 50, 14, 62, 1, 49, 13, 61},
+		{34, 18, 46, 30, 33, 17, 45, 29},
+		{10, 58, 6, 54, 9, 57, 5, 53},
+		{42, 26, 38, 22, 41, 25, 37, 21},
+	})
+)
+
+// newOrderedKernel normalizes an integer Bayer index matrix into threshold
+// values in (0, 1) via (M[x][y]+0.5)/N^2.
+func newOrderedKernel(indices [][]int) orderedKernel {
+	n := len(indices)
+	thresholds := make([][]float64, n)
+	for y, row := range indices {
+		thresholds[y] = make([]float64, n)
+		for x, idx := range row {
+			thresholds[y][x] = (float64(idx) + 0.5) / float64(n*n)
+		}
+	}
+	return orderedKernel{size: n, thresholds: thresholds}
+}
+
+// apply implements DitherKernel for errorDiffusionKernel.
+func (k errorDiffusionKernel) apply(grayMap []float64, w, h int) {
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			orig := grayMap[y*w+x]
+			new := 0.0
+			if orig >= 0.5 {
+				new = 1.0
+			}
+			grayMap[y*w+x] = new
+			quantError := orig - new
+
+			for _, off := range k.offsets {
+				nx, ny := x+off.dx, y+off.dy
+				if nx < 0 || nx >= w || ny < 0 || ny >= h {
+					continue
+				}
+				grayMap[ny*w+nx] += quantError * off.num / k.divisor
+			}
+		}
+	}
+}
+
+// apply implements DitherKernel for orderedKernel.
+func (k orderedKernel) apply(grayMap []float64, w, h int) {
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			threshold := k.thresholds[y%k.size][x%k.size]
+			if grayMap[y*w+x] > threshold {
+				grayMap[y*w+x] = 1.0
+			} else {
+				grayMap[y*w+x] = 0.0
+			}
+		}
+	}
+}
+
+// ByDitherKernel loads an Image to a Bitmap by dithering the grayscale
+// image data using the given DitherKernel.
+func ByDitherKernel(src image.Image, kernel DitherKernel) *Bitmap {
+	bmp, w, h := createFromImageSize(src)
+	grayMap := floatGrayMap(src, w, h)
+	kernel.apply(grayMap, w, h)
+	fillByGrayMap(grayMap, bmp, w, h)
+	return bmp
+}
+
+// ByDither loads an Image to a Bitmap by performing Floyd-Steinberg
+// dithering on the grayscale image data.
+//
+// Deprecated: kept for backward compatibility, equivalent to
+// ByDitherKernel(src, KernelFloydSteinberg).
+func ByDither(src image.Image) *Bitmap {
+	return ByDitherKernel(src, KernelFloydSteinberg)
+}
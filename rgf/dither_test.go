@@ -0,0 +1,102 @@
+package rgf
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// TestErrorDiffusionKernels checks each error-diffusion kernel's (1,0,...)
+// tap on a 2x1 gray map: pixel 0 quantizes to black and diffuses its error
+// forward to pixel 1. Only Floyd-Steinberg's 7/16 weight pushes pixel 1
+// past 0.5; the other kernels' smaller weights leave it black, so the bit
+// for pixel 1 separates Floyd-Steinberg from the rest but does not tell
+// the other kernels apart.
+func TestErrorDiffusionKernels(t *testing.T) {
+	cases := []struct {
+		name       string
+		kernel     DitherKernel
+		wantPixel1 float64
+	}{
+		{"FloydSteinberg", KernelFloydSteinberg, 1}, // 0.45*7/16 added to 0.40 crosses 0.5
+		{"Atkinson", KernelAtkinson, 0},
+		{"JarvisJudiceNinke", KernelJarvisJudiceNinke, 0},
+		{"Stucki", KernelStucki, 0},
+		{"Sierra", KernelSierra, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			grayMap := []float64{0.45, 0.40}
+			c.kernel.apply(grayMap, 2, 1)
+			if grayMap[0] != 0 {
+				t.Errorf("pixel 0 = %v, want 0 (quantized black)", grayMap[0])
+			}
+			if grayMap[1] != c.wantPixel1 {
+				t.Errorf("pixel 1 = %v, want %v", grayMap[1], c.wantPixel1)
+			}
+		})
+	}
+}
+
+// TestErrorDiffusionSkipsOutOfBounds exercises the edge-handling shared by
+// all error-diffusion kernels: on a 1x1 map, every offset falls outside
+// the map and must be skipped rather than panicking or wrapping around.
+func TestErrorDiffusionSkipsOutOfBounds(t *testing.T) {
+	for _, kernel := range []DitherKernel{
+		KernelFloydSteinberg, KernelAtkinson, KernelJarvisJudiceNinke, KernelStucki, KernelSierra,
+	} {
+		grayMap := []float64{0.2}
+		kernel.apply(grayMap, 1, 1)
+		if grayMap[0] != 0 {
+			t.Errorf("1x1 map quantized to %v, want 0", grayMap[0])
+		}
+	}
+}
+
+// TestOrderedKernels checks the normalized Bayer threshold values by
+// placing a single known gray value at a cell whose index is known to
+// produce a low threshold (and so should quantize to white) and another
+// whose index produces a high threshold (and so should stay black).
+func TestOrderedKernels(t *testing.T) {
+	cases := []struct {
+		name      string
+		kernel    DitherKernel
+		x, y      int
+		wantWhite bool
+	}{
+		{"Bayer4x4 lowest-index cell", KernelBayer4x4, 0, 0, true}, // index 0 -> threshold 0.03125
+		{"Bayer4x4 high-index cell", KernelBayer4x4, 1, 0, false},  // index 8 -> threshold 0.53125
+		{"Bayer8x8 lowest-index cell", KernelBayer8x8, 0, 0, true}, // index 0 -> threshold 0.0078125
+		{"Bayer8x8 high-index cell", KernelBayer8x8, 1, 0, false},  // index 48 -> threshold 0.7578125
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w, h := 2, 1
+			grayMap := make([]float64, w*h)
+			grayMap[c.y*w+c.x] = 0.5
+			c.kernel.apply(grayMap, w, h)
+			gotWhite := grayMap[c.y*w+c.x] != 0
+			if gotWhite != c.wantWhite {
+				t.Errorf("got white=%v, want %v", gotWhite, c.wantWhite)
+			}
+		})
+	}
+}
+
+// TestByDitherMatchesByDitherKernel confirms ByDither is still the
+// backward-compatible Floyd-Steinberg alias, through the public
+// image.Image-facing API rather than the unexported kernel math.
+func TestByDitherMatchesByDitherKernel(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 2, 1))
+	src.SetGray(0, 0, color.Gray{Y: 115}) // 0.45 * 255, rounded
+	src.SetGray(1, 0, color.Gray{Y: 102}) // 0.40 * 255, rounded
+
+	a := ByDither(src)
+	b := ByDitherKernel(src, KernelFloydSteinberg)
+
+	for x := uint8(0); x < 2; x++ {
+		if a.Get(x, 0) != b.Get(x, 0) {
+			t.Errorf("pixel %d: ByDither=%v, ByDitherKernel(FloydSteinberg)=%v", x, a.Get(x, 0), b.Get(x, 0))
+		}
+	}
+}
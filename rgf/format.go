@@ -0,0 +1,124 @@
+package rgf
+
+import (
+	"bufio"
+	"image"
+	"image/color"
+	"io"
+)
+
+// Register wires RGF into the standard image package's decoder registry,
+// so image.Decode and image.DecodeConfig recognize it.
+//
+// RGF has no magic number to sniff, so this registers an empty prefix,
+// which image.Decode treats as matching any input it hasn't already
+// matched to an earlier-registered format. Because init() order between
+// unrelated packages is unspecified, that makes Register a global,
+// hard-to-reason-about commitment: whichever format's init() (or explicit
+// Register call) runs first claims any bytes no more specific format
+// recognizes, and image.Decode never falls back to try another format
+// after the first match fails. Decode/DecodeConfig reject input that's
+// too short for the size its header claims, but they cannot positively
+// identify RGF the way a real magic number would.
+//
+// Call Register only from a program's own initialization, not from a
+// library, and only if you understand and accept that tradeoff. Most
+// callers should call Decode/DecodeConfig directly instead.
+func Register() {
+	image.RegisterFormat("rgf", "", Decode, DecodeConfig)
+}
+
+// maxRGFSize is the largest a well-formed RGF stream can be: a 2-byte
+// header plus a full uint8 x uint8 pixel grid.
+const maxRGFSize = 2 + 255*32
+
+// peekRGFHeader confirms r holds at least a 2-byte header plus a full
+// body of the size that header claims, without consuming r, and returns
+// the header bytes. RGF has no magic number, so this is the only sanity
+// check available before trusting width/height: it rejects input that's
+// too short to be real RGF data of the claimed size, though it cannot
+// rule out arbitrary same-sized data from another format.
+func peekRGFHeader(br *bufio.Reader) ([2]uint8, error) {
+	var header [2]uint8
+	peeked, err := br.Peek(2)
+	if err != nil {
+		return header, image.ErrFormat
+	}
+	header[0], header[1] = peeked[0], peeked[1]
+
+	stride := (int(header[0]) + 7) / 8
+	want := 2 + stride*int(header[1])
+	if _, err := br.Peek(want); err != nil {
+		return header, image.ErrFormat
+	}
+	return header, nil
+}
+
+// DecodeConfig returns the dimensions from the RGF header and its colour
+// model, without decoding pixel data. Like Decode, it first checks that r
+// holds a full body of the size the header claims, so it reads past the
+// 2-byte header.
+func DecodeConfig(r io.Reader) (image.Config, error) {
+	br := bufio.NewReaderSize(r, maxRGFSize)
+	header, err := peekRGFHeader(br)
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{
+		ColorModel: color.GrayModel,
+		Width:      int(header[0]),
+		Height:     int(header[1]),
+	}, nil
+}
+
+// Decode reads an RGF bitmap from r as an image.Image.
+func Decode(r io.Reader) (image.Image, error) {
+	br := bufio.NewReaderSize(r, maxRGFSize)
+	if _, err := peekRGFHeader(br); err != nil {
+		return nil, err
+	}
+	return Read(br)
+}
+
+// EncodeMode selects how Encode converts an image.Image down to RGF's
+// 1-bit-per-pixel format.
+type EncodeMode int
+
+const (
+	// EncodeThreshold converts every pixel by comparing it against
+	// EncodeOptions.Threshold, as ByThreshold does.
+	EncodeThreshold EncodeMode = iota
+	// EncodeDither dithers the image using EncodeOptions.Kernel, as
+	// ByDitherKernel does.
+	EncodeDither
+)
+
+// EncodeOptions configures Encode. A nil *EncodeOptions defaults to
+// EncodeThreshold with a Threshold of 128.
+type EncodeOptions struct {
+	Mode      EncodeMode
+	Threshold uint8
+	// Kernel is the dithering kernel used when Mode is EncodeDither. It
+	// defaults to KernelFloydSteinberg if left nil.
+	Kernel DitherKernel
+}
+
+// Encode writes m to w as an RGF bitmap, mirroring png.Encode's signature
+// so RGF can be used as a first-class encoder alongside png/gif/bmp.
+func Encode(w io.Writer, m image.Image, opts *EncodeOptions) error {
+	var bmp *Bitmap
+	switch {
+	case opts == nil:
+		bmp = ByThreshold(m, 128)
+	case opts.Mode == EncodeDither:
+		kernel := opts.Kernel
+		if kernel == nil {
+			kernel = KernelFloydSteinberg
+		}
+		bmp = ByDitherKernel(m, kernel)
+	default:
+		bmp = ByThreshold(m, opts.Threshold)
+	}
+	_, err := bmp.Write(w)
+	return err
+}
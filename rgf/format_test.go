@@ -0,0 +1,35 @@
+package rgf
+
+import (
+	"bytes"
+	"image/color"
+	"testing"
+)
+
+func TestDecodeConfigMatchesDecodeColorModel(t *testing.T) {
+	bmp := Create(4, 2)
+	var buf bytes.Buffer
+	if _, err := bmp.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	cfg, err := DecodeConfig(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("DecodeConfig: %v", err)
+	}
+
+	img, err := Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+
+	// color.Model values wrap funcs and are not comparable with ==, so
+	// compare what the two models actually produce for a sample color
+	// instead.
+	sample := color.Gray{Y: 128}
+	got := img.ColorModel().Convert(sample)
+	want := cfg.ColorModel.Convert(sample)
+	if got != want {
+		t.Errorf("DecodeConfig color model converts %v to %v, Decode color model converts it to %v", sample, want, got)
+	}
+}
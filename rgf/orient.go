@@ -0,0 +1,231 @@
+package rgf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+)
+
+// AutoOrient returns a view of src with the transform implied by an EXIF
+// Orientation tag value (1-8) applied. The returned image is a lazy
+// wrapper that remaps At(x, y) to the corresponding source coordinate, so
+// no intermediate buffer is allocated.
+func AutoOrient(src image.Image, orientation int) image.Image {
+	switch orientation {
+	case 2:
+		return flipHImage{src}
+	case 3:
+		return rotate180Image{src}
+	case 4:
+		return flipVImage{src}
+	case 5:
+		return transposeImage{src}
+	case 6:
+		return rotate90CWImage{src}
+	case 7:
+		return transverseImage{src}
+	case 8:
+		return rotate90CCWImage{src}
+	default:
+		return src
+	}
+}
+
+// flipHImage mirrors src left-to-right.
+type flipHImage struct{ src image.Image }
+
+func (img flipHImage) Bounds() image.Rectangle { return img.src.Bounds() }
+func (img flipHImage) ColorModel() color.Model { return img.src.ColorModel() }
+func (img flipHImage) At(x, y int) color.Color {
+	b := img.src.Bounds()
+	return img.src.At(b.Min.X+b.Max.X-1-x, y)
+}
+
+// rotate180Image rotates src by 180 degrees.
+type rotate180Image struct{ src image.Image }
+
+func (img rotate180Image) Bounds() image.Rectangle { return img.src.Bounds() }
+func (img rotate180Image) ColorModel() color.Model { return img.src.ColorModel() }
+func (img rotate180Image) At(x, y int) color.Color {
+	b := img.src.Bounds()
+	return img.src.At(b.Min.X+b.Max.X-1-x, b.Min.Y+b.Max.Y-1-y)
+}
+
+// flipVImage mirrors src top-to-bottom.
+type flipVImage struct{ src image.Image }
+
+func (img flipVImage) Bounds() image.Rectangle { return img.src.Bounds() }
+func (img flipVImage) ColorModel() color.Model { return img.src.ColorModel() }
+func (img flipVImage) At(x, y int) color.Color {
+	b := img.src.Bounds()
+	return img.src.At(x, b.Min.Y+b.Max.Y-1-y)
+}
+
+// transposeImage mirrors src across its top-left/bottom-right diagonal,
+// swapping width and height.
+type transposeImage struct{ src image.Image }
+
+func (img transposeImage) Bounds() image.Rectangle {
+	b := img.src.Bounds()
+	return image.Rect(0, 0, b.Dy(), b.Dx())
+}
+func (img transposeImage) ColorModel() color.Model { return img.src.ColorModel() }
+func (img transposeImage) At(x, y int) color.Color {
+	b := img.src.Bounds()
+	return img.src.At(b.Min.X+y, b.Min.Y+x)
+}
+
+// rotate90CWImage rotates src 90 degrees clockwise, swapping width and
+// height.
+type rotate90CWImage struct{ src image.Image }
+
+func (img rotate90CWImage) Bounds() image.Rectangle {
+	b := img.src.Bounds()
+	return image.Rect(0, 0, b.Dy(), b.Dx())
+}
+func (img rotate90CWImage) ColorModel() color.Model { return img.src.ColorModel() }
+func (img rotate90CWImage) At(x, y int) color.Color {
+	b := img.src.Bounds()
+	return img.src.At(b.Min.X+y, b.Max.Y-1-x)
+}
+
+// transverseImage mirrors src across its top-right/bottom-left diagonal,
+// swapping width and height.
+type transverseImage struct{ src image.Image }
+
+func (img transverseImage) Bounds() image.Rectangle {
+	b := img.src.Bounds()
+	return image.Rect(0, 0, b.Dy(), b.Dx())
+}
+func (img transverseImage) ColorModel() color.Model { return img.src.ColorModel() }
+func (img transverseImage) At(x, y int) color.Color {
+	b := img.src.Bounds()
+	return img.src.At(b.Max.X-1-y, b.Max.Y-1-x)
+}
+
+// rotate90CCWImage rotates src 90 degrees counter-clockwise, swapping
+// width and height.
+type rotate90CCWImage struct{ src image.Image }
+
+func (img rotate90CCWImage) Bounds() image.Rectangle {
+	b := img.src.Bounds()
+	return image.Rect(0, 0, b.Dy(), b.Dx())
+}
+func (img rotate90CCWImage) ColorModel() color.Model { return img.src.ColorModel() }
+func (img rotate90CCWImage) At(x, y int) color.Color {
+	b := img.src.Bounds()
+	return img.src.At(b.Max.X-1-y, b.Min.Y+x)
+}
+
+// exifOrientationTag is the TIFF tag number of the EXIF Orientation field.
+const exifOrientationTag = 0x0112
+
+// ReadImage decodes r as a JPEG and, if it carries an EXIF Orientation
+// tag, wraps the result with AutoOrient so the returned image already has
+// the correct "up" side. Callers that need to handle other formats should
+// decode them directly and call AutoOrient themselves if applicable.
+//
+// Pipe image decoders through ReadImage (or AutoOrient) before passing
+// their output to ByThreshold or ByDither/ByDitherKernel, otherwise
+// camera photos may come out sideways or upside down.
+func ReadImage(r io.Reader) (image.Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode via the jpeg package explicitly rather than image.Decode:
+	// this package's own Register (see format.go) can claim the
+	// registry's empty-prefix slot, so going through the global registry
+	// here could hand these bytes back to rgf.Decode instead of a real
+	// JPEG decoder.
+	src, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	orientation, err := readEXIFOrientation(data)
+	if err != nil {
+		// No (usable) EXIF data is not an error: just skip orientation.
+		return src, nil
+	}
+	return AutoOrient(src, orientation), nil
+}
+
+// readEXIFOrientation scans a JPEG byte stream for an APP1 "Exif" segment
+// and returns the value of its Orientation tag (0x0112).
+func readEXIFOrientation(data []byte) (int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, errors.New("not a JPEG file")
+	}
+
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return 0, errors.New("malformed JPEG marker")
+		}
+		marker := data[pos+1]
+		if marker == 0xD8 || marker == 0xD9 || (marker >= 0xD0 && marker <= 0xD7) {
+			pos += 2
+			continue
+		}
+		segLen := int(data[pos+2])<<8 | int(data[pos+3])
+		segStart := pos + 4
+		segEnd := pos + 2 + segLen
+		if segEnd > len(data) {
+			break
+		}
+
+		if marker == 0xE1 && segEnd-segStart >= 6 && string(data[segStart:segStart+6]) == "Exif\x00\x00" {
+			return parseEXIFOrientation(data[segStart+6 : segEnd])
+		}
+		if marker == 0xDA {
+			break // start of scan data: no more markers to scan
+		}
+		pos = segEnd
+	}
+	return 0, errors.New("no EXIF Orientation tag found")
+}
+
+// parseEXIFOrientation parses a TIFF header plus its 0th IFD looking for
+// the Orientation tag (0x0112).
+func parseEXIFOrientation(tiff []byte) (int, error) {
+	if len(tiff) < 8 {
+		return 0, errors.New("EXIF header is truncated")
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, errors.New("unrecognized TIFF byte order")
+	}
+
+	ifdOffset := int(order.Uint32(tiff[4:8]))
+	if ifdOffset+2 > len(tiff) {
+		return 0, errors.New("EXIF IFD offset is out of range")
+	}
+
+	entryCount := int(order.Uint16(tiff[ifdOffset : ifdOffset+2]))
+	entriesStart := ifdOffset + 2
+	for i := 0; i < entryCount; i++ {
+		entry := entriesStart + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		tag := order.Uint16(tiff[entry : entry+2])
+		if tag != exifOrientationTag {
+			continue
+		}
+		value := order.Uint16(tiff[entry+8 : entry+10])
+		return int(value), nil
+	}
+	return 0, errors.New("no EXIF Orientation tag found")
+}
@@ -0,0 +1,46 @@
+package rgf
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// newTestGray builds a *image.Gray whose Bounds() does not start at the
+// origin, so coordinate-mapping bugs that only cancel out Min.X/Min.Y by
+// coincidence at (0,0) are caught.
+func newTestGray(b image.Rectangle) *image.Gray {
+	img := image.NewGray(b)
+	v := uint8(0)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			img.SetGray(x, y, color.Gray{Y: v})
+			v++
+		}
+	}
+	return img
+}
+
+func TestAutoOrientNonOriginBounds(t *testing.T) {
+	b := image.Rect(5, 5, 9, 8) // width 4, height 3, not anchored at (0, 0)
+	src := newTestGray(b)
+
+	cases := []struct {
+		orientation int
+		wantSrcX    int
+		wantSrcY    int
+	}{
+		{6, 5, 7}, // rotate 90 CW
+		{7, 8, 7}, // transverse
+		{8, 8, 5}, // rotate 90 CCW
+	}
+
+	for _, c := range cases {
+		got := AutoOrient(src, c.orientation).At(0, 0)
+		want := src.At(c.wantSrcX, c.wantSrcY)
+		if got != want {
+			t.Errorf("orientation %d: At(0,0) = %v, want src.At(%d,%d) = %v",
+				c.orientation, got, c.wantSrcX, c.wantSrcY, want)
+		}
+	}
+}
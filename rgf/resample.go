@@ -0,0 +1,338 @@
+package rgf
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// ResampleFilter is a separable reconstruction filter used by Resample.
+// Weight returns the filter's contribution at distance t (in source
+// pixels) from the sample centre; it is expected to be zero outside
+// [-Radius, Radius].
+type ResampleFilter struct {
+	Radius float64
+	Weight func(t float64) float64
+	// FixedRadius keeps Radius constant when downscaling instead of
+	// widening it to average away aliasing. NearestNeighbor sets this so
+	// it keeps picking a single source pixel rather than turning into a
+	// box filter.
+	FixedRadius bool
+}
+
+var (
+	// NearestNeighbor picks the closest source pixel. It is fast but blocky.
+	NearestNeighbor = ResampleFilter{
+		Radius: 0.5,
+		Weight: func(t float64) float64 {
+			if t > -0.5 && t <= 0.5 {
+				return 1
+			}
+			return 0
+		},
+		FixedRadius: true,
+	}
+
+	// Bilinear linearly interpolates between the two closest source pixels.
+	Bilinear = ResampleFilter{
+		Radius: 1,
+		Weight: func(t float64) float64 {
+			t = math.Abs(t)
+			if t < 1 {
+				return 1 - t
+			}
+			return 0
+		},
+	}
+
+	// Bicubic is a Mitchell-Netravali filter with B=1/3, C=1/3, a good
+	// general-purpose compromise between sharpness and ringing.
+	Bicubic = ResampleFilter{
+		Radius: 2,
+		Weight: mitchellNetravali(1.0/3, 1.0/3),
+	}
+
+	// Lanczos3 is a 3-lobe Lanczos filter. It is the sharpest of the
+	// built-in filters, at the cost of some ringing near hard edges.
+	Lanczos3 = ResampleFilter{
+		Radius: 3,
+		Weight: func(t float64) float64 {
+			if t == 0 {
+				return 1
+			}
+			if t <= -3 || t >= 3 {
+				return 0
+			}
+			return sinc(t) * sinc(t/3)
+		},
+	}
+)
+
+// sinc is the normalized sinc function, sin(pi*x)/(pi*x).
+func sinc(x float64) float64 {
+	x *= math.Pi
+	return math.Sin(x) / x
+}
+
+// mitchellNetravali builds the Mitchell-Netravali cubic filter for the
+// given B and C parameters.
+func mitchellNetravali(b, c float64) func(float64) float64 {
+	return func(t float64) float64 {
+		t = math.Abs(t)
+		switch {
+		case t < 1:
+			return ((12-9*b-6*c)*t*t*t + (-18+12*b+6*c)*t*t + (6 - 2*b)) / 6
+		case t < 2:
+			return ((-b-6*c)*t*t*t + (6*b+30*c)*t*t + (-12*b-48*c)*t + (8*b + 24*c)) / 6
+		default:
+			return 0
+		}
+	}
+}
+
+// resampleWeight is one (source index, normalized weight) contribution to
+// a destination sample.
+type resampleWeight struct {
+	index  int
+	weight float64
+}
+
+// resampleWeights computes, for every destination sample along one axis,
+// the list of source samples within the filter radius and their weights,
+// normalized to sum to 1. Building this once per axis keeps Resample at
+// O(W*H*radius) instead of re-evaluating the filter per output pixel pair.
+func resampleWeights(srcN, dstN int, filter ResampleFilter) [][]resampleWeight {
+	scale := float64(srcN) / float64(dstN)
+	// When downscaling, widen the filter support proportionally so every
+	// source sample still contributes to the (now sparser) output.
+	filterScale := scale
+	if filterScale < 1 || filter.FixedRadius {
+		filterScale = 1
+	}
+	radius := filter.Radius * filterScale
+
+	weights := make([][]resampleWeight, dstN)
+	for d := 0; d < dstN; d++ {
+		center := (float64(d)+0.5)*scale - 0.5
+		lo := int(math.Floor(center - radius))
+		hi := int(math.Ceil(center + radius))
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > srcN-1 {
+			hi = srcN - 1
+		}
+
+		var row []resampleWeight
+		var sum float64
+		for s := lo; s <= hi; s++ {
+			w := filter.Weight((float64(s) - center) / filterScale)
+			if w == 0 {
+				continue
+			}
+			row = append(row, resampleWeight{index: s, weight: w})
+			sum += w
+		}
+		if sum != 0 {
+			for i := range row {
+				row[i].weight /= sum
+			}
+		}
+		weights[d] = row
+	}
+	return weights
+}
+
+// Resample converts src to an image of exactly dstW x dstH pixels using
+// filter, by applying separable weighted sums: first along x, then along y.
+func Resample(src image.Image, dstW, dstH uint8, filter ResampleFilter) image.Image {
+	w, h := int(dstW), int(dstH)
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	dst := image.NewNRGBA64(image.Rect(0, 0, w, h))
+	if w == 0 || h == 0 || sw == 0 || sh == 0 {
+		return dst
+	}
+
+	xWeights := resampleWeights(sw, w, filter)
+	yWeights := resampleWeights(sh, h, filter)
+
+	// Horizontal pass: sw x sh source -> w x sh intermediate, kept as
+	// float64 RGBA to avoid clamping before the vertical pass runs.
+	type rgba struct{ r, g, b, a float64 }
+	horiz := make([]rgba, w*sh)
+	for y := 0; y < sh; y++ {
+		for x := 0; x < w; x++ {
+			var sum rgba
+			for _, sample := range xWeights[x] {
+				r, g, bl, a := src.At(b.Min.X+sample.index, b.Min.Y+y).RGBA()
+				sum.r += float64(r) * sample.weight
+				sum.g += float64(g) * sample.weight
+				sum.b += float64(bl) * sample.weight
+				sum.a += float64(a) * sample.weight
+			}
+			horiz[y*w+x] = sum
+		}
+	}
+
+	// Vertical pass: w x sh intermediate -> w x h destination.
+	for x := 0; x < w; x++ {
+		for y := 0; y < h; y++ {
+			var sum rgba
+			for _, sample := range yWeights[y] {
+				px := horiz[sample.index*w+x]
+				sum.r += px.r * sample.weight
+				sum.g += px.g * sample.weight
+				sum.b += px.b * sample.weight
+				sum.a += px.a * sample.weight
+			}
+			dst.SetNRGBA64(x, y, color.NRGBA64{
+				R: clampChannel16(sum.r),
+				G: clampChannel16(sum.g),
+				B: clampChannel16(sum.b),
+				A: clampChannel16(sum.a),
+			})
+		}
+	}
+	return dst
+}
+
+// clampChannel16 clamps a resampled, alpha-premultiplied 16-bit channel
+// value to the valid uint16 range.
+func clampChannel16(v float64) uint16 {
+	if v < 0 {
+		return 0
+	}
+	if v > 0xffff {
+		return 0xffff
+	}
+	return uint16(v + 0.5)
+}
+
+// FitMode controls how FitLCD maps an arbitrarily sized image onto the
+// fixed EV3 LCD dimensions.
+type FitMode int
+
+const (
+	// FitStretch resizes the source to exactly fill the LCD, ignoring
+	// its aspect ratio.
+	FitStretch FitMode = iota
+	// FitContain scales the source to fit entirely within the LCD,
+	// letterboxing any remaining area in white.
+	FitContain
+	// FitCover scales the source to fill the LCD entirely, cropping
+	// whichever dimension overflows.
+	FitCover
+)
+
+// lcdWidth and lcdHeight are the EV3 brick's LCD dimensions, in pixels.
+const (
+	lcdWidth  uint8 = 178
+	lcdHeight uint8 = 128
+)
+
+// FitLCD resamples src to the EV3 LCD dimensions (178x128) using filter,
+// following mode to decide how to handle a mismatched aspect ratio. The
+// result can be passed directly to ByThreshold or ByDither/ByDitherKernel,
+// e.g. rgf.ByDither(rgf.FitLCD(src, rgf.Lanczos3, rgf.FitContain)).
+func FitLCD(src image.Image, filter ResampleFilter, mode FitMode) image.Image {
+	return fitTo(src, lcdWidth, lcdHeight, filter, mode)
+}
+
+// fitTo resamples src to dstW x dstH following mode.
+func fitTo(src image.Image, dstW, dstH uint8, filter ResampleFilter, mode FitMode) image.Image {
+	if mode == FitStretch {
+		return Resample(src, dstW, dstH, filter)
+	}
+
+	b := src.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	if sw == 0 || sh == 0 {
+		return Resample(src, dstW, dstH, filter)
+	}
+
+	scaleX := float64(dstW) / float64(sw)
+	scaleY := float64(dstH) / float64(sh)
+	scale := scaleX
+	if (mode == FitContain && scaleY < scaleX) || (mode == FitCover && scaleY > scaleX) {
+		scale = scaleY
+	}
+
+	rw := clampDim(float64(sw) * scale)
+	rh := clampDim(float64(sh) * scale)
+	resized := Resample(src, rw, rh, filter)
+
+	if mode == FitCover {
+		return centerCrop(resized, dstW, dstH)
+	}
+	return letterbox(resized, dstW, dstH)
+}
+
+// clampDim rounds v to the nearest uint8, keeping it within [1, 255].
+func clampDim(v float64) uint8 {
+	if v < 1 {
+		return 1
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(math.Round(v))
+}
+
+// letterboxImage lazily pads src with white to centre it within a
+// dstW x dstH canvas, with no intermediate buffer.
+type letterboxImage struct {
+	src        image.Image
+	w, h       int
+	offX, offY int
+}
+
+// letterbox centres src within a dstW x dstH white canvas.
+func letterbox(src image.Image, dstW, dstH uint8) image.Image {
+	b := src.Bounds()
+	return letterboxImage{
+		src:  src,
+		w:    int(dstW),
+		h:    int(dstH),
+		offX: (int(dstW) - b.Dx()) / 2,
+		offY: (int(dstH) - b.Dy()) / 2,
+	}
+}
+
+func (img letterboxImage) Bounds() image.Rectangle { return image.Rect(0, 0, img.w, img.h) }
+func (img letterboxImage) ColorModel() color.Model { return img.src.ColorModel() }
+func (img letterboxImage) At(x, y int) color.Color {
+	b := img.src.Bounds()
+	sx, sy := x-img.offX+b.Min.X, y-img.offY+b.Min.Y
+	if !(image.Point{sx, sy}.In(b)) {
+		return color.White
+	}
+	return img.src.At(sx, sy)
+}
+
+// centerCropImage lazily crops src to dstW x dstH around its centre, with
+// no intermediate buffer.
+type centerCropImage struct {
+	src        image.Image
+	w, h       int
+	offX, offY int
+}
+
+// centerCrop crops src to dstW x dstH around its centre.
+func centerCrop(src image.Image, dstW, dstH uint8) image.Image {
+	b := src.Bounds()
+	return centerCropImage{
+		src:  src,
+		w:    int(dstW),
+		h:    int(dstH),
+		offX: (b.Dx() - int(dstW)) / 2,
+		offY: (b.Dy() - int(dstH)) / 2,
+	}
+}
+
+func (img centerCropImage) Bounds() image.Rectangle { return image.Rect(0, 0, img.w, img.h) }
+func (img centerCropImage) ColorModel() color.Model { return img.src.ColorModel() }
+func (img centerCropImage) At(x, y int) color.Color {
+	b := img.src.Bounds()
+	return img.src.At(x+img.offX+b.Min.X, y+img.offY+b.Min.Y)
+}
@@ -0,0 +1,26 @@
+package rgf
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestResampleNearestNeighborDoesNotBlend(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 10, 1))
+	for x := 0; x < 10; x++ {
+		v := uint8(0)
+		if x%2 == 1 {
+			v = 255
+		}
+		src.SetGray(x, 0, color.Gray{Y: v})
+	}
+
+	dst := Resample(src, 2, 1, NearestNeighbor)
+	for x := 0; x < 2; x++ {
+		lum := colorToGray(dst.At(x, 0)).Y
+		if lum != 0 && lum != 255 {
+			t.Errorf("pixel %d = %d, want a literal sampled 0 or 255, not a blend", x, lum)
+		}
+	}
+}
@@ -117,7 +117,7 @@ func (img *Bitmap) At(x, y int) color.Color {
 
 // ColorModel returns 'image' pkg compatible color model.
 func (img *Bitmap) ColorModel() color.Model {
-	return color.RGBAModel
+	return color.GrayModel
 }
 
 // colorToGray converts any color to a grayscale color.
@@ -174,34 +174,6 @@ func floatGrayMap(src image.Image, w, h int) (grayMap []float64) {
 	return
 }
 
-// floydSteinbergDither applies Floyd-Steinberg dithering
-// to a float64 gray map.
-// Algorithm at https://en.wikipedia.org/wiki/Floyd%E2%80%93Steinberg_dithering
-func floydSteinbergDither(grayMap []float64, w, h int) {
-	for y := 0; y < h; y++ {
-		for x := 0; x < w; x++ {
-			orig := grayMap[y*w+x]
-			new := 0.0
-			if orig >= 0.5 {
-				new = 1.0
-			}
-			grayMap[y*w+x] = new
-			quantError := orig - new
-
-			if x != w-1 {
-				grayMap[(y+0)*w+x+1] += quantError * 7 / 16
-			}
-			if y != h-1 {
-				grayMap[(y+1)*w+x-1] += quantError * 3 / 16
-				grayMap[(y+1)*w+x+0] += quantError * 5 / 16
-				if x != w-1 {
-					grayMap[(y+1)*w+x+1] += quantError * 1 / 16
-				}
-			}
-		}
-	}
-}
-
 // fillByGrayMap imports a float gray map to a Bitmap via
 // a 50% threshold value.
 func fillByGrayMap(src []float64, dst *Bitmap, w, h int) {
@@ -212,13 +184,3 @@ func fillByGrayMap(src []float64, dst *Bitmap, w, h int) {
 		}
 	}
 }
-
-// ByThreshold loads an Image to a Bitmap by performing
-// Floyd-Steinberg dithering on the grayscale image data.
-func ByDither(src image.Image) *Bitmap {
-	bmp, w, h := createFromImageSize(src)
-	grayMap := floatGrayMap(src, w, h)
-	floydSteinbergDither(grayMap, w, h)
-	fillByGrayMap(grayMap, bmp, w, h)
-	return bmp
-}
\ No newline at end of file